@@ -0,0 +1,152 @@
+// Package camera implements a first-person fly camera with mouse look
+// and keyboard movement, suitable for driving the view matrix of a
+// perspective scene.
+package camera
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Direction identifies a movement input passed to ProcessKeyboard.
+type Direction int
+
+const (
+	Forward Direction = iota
+	Backward
+	Left
+	Right
+)
+
+const (
+	defaultYaw         = -90.0
+	defaultPitch       = 0.0
+	defaultSpeed       = 2.5
+	defaultSensitivity = 0.1
+	defaultFOV         = 45.0
+	minPitch           = -89.0
+	maxPitch           = 89.0
+	minFOV             = 1.0
+	maxFOV             = 45.0
+)
+
+// Camera tracks position and orientation and derives the basis vectors
+// and view matrix used to render the scene from its point of view.
+type Camera struct {
+	Position mgl32.Vec3
+	Front    mgl32.Vec3
+	Up       mgl32.Vec3
+	Right    mgl32.Vec3
+	WorldUp  mgl32.Vec3
+
+	Yaw   float32
+	Pitch float32
+
+	MovementSpeed    float32
+	MouseSensitivity float32
+	FOV              float32
+
+	moveForward  bool
+	moveBackward bool
+	moveLeft     bool
+	moveRight    bool
+}
+
+// New creates a Camera positioned at pos, looking down -Z with +Y up,
+// and computes its initial basis vectors.
+func New(pos mgl32.Vec3) *Camera {
+	c := &Camera{
+		Position:         pos,
+		WorldUp:          mgl32.Vec3{0, 1, 0},
+		Yaw:              defaultYaw,
+		Pitch:            defaultPitch,
+		MovementSpeed:    defaultSpeed,
+		MouseSensitivity: defaultSensitivity,
+		FOV:              defaultFOV,
+	}
+	c.updateVectors()
+	return c
+}
+
+// ViewMatrix returns the current look-at matrix for this camera.
+func (c *Camera) ViewMatrix() mgl32.Mat4 {
+	return mgl32.LookAtV(c.Position, c.Position.Add(c.Front), c.Up)
+}
+
+// SetMoving marks dir as held or released for the next ProcessKeyboard
+// call; call it from key callbacks or per-frame polling.
+func (c *Camera) SetMoving(dir Direction, active bool) {
+	switch dir {
+	case Forward:
+		c.moveForward = active
+	case Backward:
+		c.moveBackward = active
+	case Left:
+		c.moveLeft = active
+	case Right:
+		c.moveRight = active
+	}
+}
+
+// ProcessKeyboard advances the camera position according to whichever
+// directions are currently marked active, scaled by dt.
+func (c *Camera) ProcessKeyboard(dt float32) {
+	velocity := c.MovementSpeed * dt
+	if c.moveForward {
+		c.Position = c.Position.Add(c.Front.Mul(velocity))
+	}
+	if c.moveBackward {
+		c.Position = c.Position.Sub(c.Front.Mul(velocity))
+	}
+	if c.moveLeft {
+		c.Position = c.Position.Sub(c.Right.Mul(velocity))
+	}
+	if c.moveRight {
+		c.Position = c.Position.Add(c.Right.Mul(velocity))
+	}
+}
+
+// ProcessMouse applies a raw mouse-position delta to yaw/pitch,
+// clamping pitch to avoid the view flipping at the poles.
+func (c *Camera) ProcessMouse(dx, dy float32) {
+	c.Yaw += dx * c.MouseSensitivity
+	c.Pitch += dy * c.MouseSensitivity
+
+	if c.Pitch > maxPitch {
+		c.Pitch = maxPitch
+	}
+	if c.Pitch < minPitch {
+		c.Pitch = minPitch
+	}
+
+	c.updateVectors()
+}
+
+// ProcessScroll zooms the camera by adjusting its field of view,
+// clamped to a sane range.
+func (c *Camera) ProcessScroll(dy float32) {
+	c.FOV -= dy
+	if c.FOV < minFOV {
+		c.FOV = minFOV
+	}
+	if c.FOV > maxFOV {
+		c.FOV = maxFOV
+	}
+}
+
+// updateVectors recomputes Front, Right and Up from the current
+// yaw/pitch angles.
+func (c *Camera) updateVectors() {
+	yaw := mgl32.DegToRad(c.Yaw)
+	pitch := mgl32.DegToRad(c.Pitch)
+
+	front := mgl32.Vec3{
+		float32(math.Cos(float64(pitch)) * math.Cos(float64(yaw))),
+		float32(math.Sin(float64(pitch))),
+		float32(math.Cos(float64(pitch)) * math.Sin(float64(yaw))),
+	}
+	c.Front = front.Normalize()
+	c.Right = c.Front.Cross(c.WorldUp).Normalize()
+	c.Up = c.Right.Cross(c.Front).Normalize()
+}
@@ -0,0 +1,169 @@
+// Package gldebug wires up OpenGL's KHR_debug output and a manual
+// error-checking helper for call sites the driver doesn't cover.
+//
+// KHR_debug was only promoted to core in GL 4.3, one version past the
+// v3.3-core context the rest of the module requests, so this package
+// imports its own v4.3-core binding rather than bumping the module-wide
+// one. It loads that binding's function pointers itself (see
+// ensureInit) and checks at runtime that the context actually supports
+// GL 4.3 or the KHR_debug extension before touching it.
+package gldebug
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.3-core/gl"
+)
+
+// initOnce guards loading this package's own GL function pointers,
+// independent of whatever v3.3-core binding the rest of the module
+// initialized via gl.Init().
+var initOnce sync.Once
+
+func ensureInit() {
+	initOnce.Do(func() {
+		if err := gl.Init(); err != nil {
+			log.Printf("gldebug: gl.Init: %v", err)
+		}
+	})
+}
+
+// supported reports whether the current context is GL 4.3+ (where
+// KHR_debug was promoted to core) or separately advertises the
+// KHR_debug extension.
+func supported() bool {
+	var major, minor int32
+	gl.GetIntegerv(gl.MAJOR_VERSION, &major)
+	gl.GetIntegerv(gl.MINOR_VERSION, &minor)
+	if major > 4 || (major == 4 && minor >= 3) {
+		return true
+	}
+
+	var numExtensions int32
+	gl.GetIntegerv(gl.NUM_EXTENSIONS, &numExtensions)
+	for i := int32(0); i < numExtensions; i++ {
+		if gl.GoStr(gl.GetStringi(gl.EXTENSIONS, uint32(i))) == "GL_KHR_debug" {
+			return true
+		}
+	}
+	return false
+}
+
+// Enabled reports whether GL debug output should be turned on: either
+// this binary was built with `-tags debug`, or the
+// OPENGL_TESTING_DEBUG environment variable is set to a non-empty
+// value.
+func Enabled() bool {
+	return buildTagEnabled || os.Getenv("OPENGL_TESTING_DEBUG") != ""
+}
+
+// Enable turns on synchronous KHR_debug output and routes driver
+// messages through Go's log package with decoded enum names. Call it
+// only after the GL context is current. If the context doesn't
+// advertise GL 4.3 or the KHR_debug extension, Enable logs and returns
+// without touching any of the KHR_debug entry points.
+func Enable() {
+	ensureInit()
+	if !supported() {
+		log.Print("gldebug: GL_KHR_debug unavailable (needs GL 4.3 or the KHR_debug extension); debug output disabled")
+		return
+	}
+
+	gl.Enable(gl.DEBUG_OUTPUT)
+	gl.Enable(gl.DEBUG_OUTPUT_SYNCHRONOUS)
+	gl.DebugMessageCallback(func(source, gltype, id, severity uint32, length int32, message string, userParam unsafe.Pointer) {
+		log.Printf("gl debug: source=%s type=%s id=%d severity=%s: %s",
+			sourceName(source), typeName(gltype), id, severityName(severity), message)
+	}, nil)
+}
+
+// CheckError decodes the current GL error state, if any, and logs it
+// tagged with where (typically the call site that might have failed).
+// GetError is core as far back as GL 1.1, so this works regardless of
+// whether Enable found KHR_debug support.
+func CheckError(where string) {
+	ensureInit()
+	if err := gl.GetError(); err != gl.NO_ERROR {
+		log.Printf("gl error at %s: %s", where, errorName(err))
+	}
+}
+
+func errorName(err uint32) string {
+	switch err {
+	case gl.INVALID_ENUM:
+		return "GL_INVALID_ENUM"
+	case gl.INVALID_VALUE:
+		return "GL_INVALID_VALUE"
+	case gl.INVALID_OPERATION:
+		return "GL_INVALID_OPERATION"
+	case gl.INVALID_FRAMEBUFFER_OPERATION:
+		return "GL_INVALID_FRAMEBUFFER_OPERATION"
+	case gl.OUT_OF_MEMORY:
+		return "GL_OUT_OF_MEMORY"
+	case gl.STACK_UNDERFLOW:
+		return "GL_STACK_UNDERFLOW"
+	case gl.STACK_OVERFLOW:
+		return "GL_STACK_OVERFLOW"
+	default:
+		return fmt.Sprintf("GL_UNKNOWN_ERROR(0x%X)", err)
+	}
+}
+
+func sourceName(source uint32) string {
+	switch source {
+	case gl.DEBUG_SOURCE_API:
+		return "GL_DEBUG_SOURCE_API"
+	case gl.DEBUG_SOURCE_WINDOW_SYSTEM:
+		return "GL_DEBUG_SOURCE_WINDOW_SYSTEM"
+	case gl.DEBUG_SOURCE_SHADER_COMPILER:
+		return "GL_DEBUG_SOURCE_SHADER_COMPILER"
+	case gl.DEBUG_SOURCE_THIRD_PARTY:
+		return "GL_DEBUG_SOURCE_THIRD_PARTY"
+	case gl.DEBUG_SOURCE_APPLICATION:
+		return "GL_DEBUG_SOURCE_APPLICATION"
+	case gl.DEBUG_SOURCE_OTHER:
+		return "GL_DEBUG_SOURCE_OTHER"
+	default:
+		return fmt.Sprintf("GL_DEBUG_SOURCE_UNKNOWN(0x%X)", source)
+	}
+}
+
+func typeName(gltype uint32) string {
+	switch gltype {
+	case gl.DEBUG_TYPE_ERROR:
+		return "GL_DEBUG_TYPE_ERROR"
+	case gl.DEBUG_TYPE_DEPRECATED_BEHAVIOR:
+		return "GL_DEBUG_TYPE_DEPRECATED_BEHAVIOR"
+	case gl.DEBUG_TYPE_UNDEFINED_BEHAVIOR:
+		return "GL_DEBUG_TYPE_UNDEFINED_BEHAVIOR"
+	case gl.DEBUG_TYPE_PORTABILITY:
+		return "GL_DEBUG_TYPE_PORTABILITY"
+	case gl.DEBUG_TYPE_PERFORMANCE:
+		return "GL_DEBUG_TYPE_PERFORMANCE"
+	case gl.DEBUG_TYPE_MARKER:
+		return "GL_DEBUG_TYPE_MARKER"
+	case gl.DEBUG_TYPE_OTHER:
+		return "GL_DEBUG_TYPE_OTHER"
+	default:
+		return fmt.Sprintf("GL_DEBUG_TYPE_UNKNOWN(0x%X)", gltype)
+	}
+}
+
+func severityName(severity uint32) string {
+	switch severity {
+	case gl.DEBUG_SEVERITY_HIGH:
+		return "GL_DEBUG_SEVERITY_HIGH"
+	case gl.DEBUG_SEVERITY_MEDIUM:
+		return "GL_DEBUG_SEVERITY_MEDIUM"
+	case gl.DEBUG_SEVERITY_LOW:
+		return "GL_DEBUG_SEVERITY_LOW"
+	case gl.DEBUG_SEVERITY_NOTIFICATION:
+		return "GL_DEBUG_SEVERITY_NOTIFICATION"
+	default:
+		return fmt.Sprintf("GL_DEBUG_SEVERITY_UNKNOWN(0x%X)", severity)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build debug
+
+package gldebug
+
+// buildTagEnabled is true when the binary was built with `-tags debug`.
+const buildTagEnabled = true
@@ -0,0 +1,107 @@
+// Package lighting holds the Phong material and light descriptions
+// used to shade the scene, and the glue to upload them to a shader
+// program's uniforms.
+package lighting
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+
+	"opengl-testing/shader"
+)
+
+// Material describes how a surface reflects each component of Phong
+// lighting. Diffuse and Specular are texture units (see
+// texture.Texture.Bind) sampled for the diffuse and specular color at
+// each fragment; there is no separate ambient map, since the ambient
+// term is just the diffuse map scaled by a light's ambient color.
+type Material struct {
+	Diffuse   int32
+	Specular  int32
+	Shininess float32
+}
+
+// Upload sets the fields of the Material uniform named name (e.g.
+// "material") on program.
+func (m Material) Upload(program *shader.Program, name string) {
+	program.SetInt(name+".diffuse", m.Diffuse)
+	program.SetInt(name+".specular", m.Specular)
+	program.SetFloat(name+".shininess", m.Shininess)
+}
+
+// DirectionalLight is a light with parallel rays, such as the sun.
+type DirectionalLight struct {
+	Direction mgl32.Vec3
+
+	Ambient  mgl32.Vec3
+	Diffuse  mgl32.Vec3
+	Specular mgl32.Vec3
+}
+
+// Upload sets the fields of the DirLight uniform named name (e.g.
+// "dirLight") on program.
+func (l DirectionalLight) Upload(program *shader.Program, name string) {
+	program.SetVec3(name+".direction", l.Direction)
+	program.SetVec3(name+".ambient", l.Ambient)
+	program.SetVec3(name+".diffuse", l.Diffuse)
+	program.SetVec3(name+".specular", l.Specular)
+}
+
+// PointLight radiates from Position in all directions, attenuated
+// over distance by Constant, Linear and Quadratic.
+type PointLight struct {
+	Position mgl32.Vec3
+
+	Constant  float32
+	Linear    float32
+	Quadratic float32
+
+	Ambient  mgl32.Vec3
+	Diffuse  mgl32.Vec3
+	Specular mgl32.Vec3
+}
+
+// Upload sets the fields of the PointLight uniform named name (e.g.
+// "pointLight") on program.
+func (l PointLight) Upload(program *shader.Program, name string) {
+	program.SetVec3(name+".position", l.Position)
+	program.SetFloat(name+".constant", l.Constant)
+	program.SetFloat(name+".linear", l.Linear)
+	program.SetFloat(name+".quadratic", l.Quadratic)
+	program.SetVec3(name+".ambient", l.Ambient)
+	program.SetVec3(name+".diffuse", l.Diffuse)
+	program.SetVec3(name+".specular", l.Specular)
+}
+
+// SpotLight is a PointLight restricted to a cone defined by CutOff
+// (inner, full-intensity angle) and OuterCutOff (outer, zero-intensity
+// angle), both expressed as cosines.
+type SpotLight struct {
+	Position  mgl32.Vec3
+	Direction mgl32.Vec3
+
+	CutOff      float32
+	OuterCutOff float32
+
+	Constant  float32
+	Linear    float32
+	Quadratic float32
+
+	Ambient  mgl32.Vec3
+	Diffuse  mgl32.Vec3
+	Specular mgl32.Vec3
+}
+
+// Upload sets the fields of the SpotLight uniform named name (e.g.
+// "spotLight") on program.
+func (l SpotLight) Upload(program *shader.Program, name string) {
+	program.SetVec3(name+".position", l.Position)
+	program.SetVec3(name+".direction", l.Direction)
+	program.SetFloat(name+".cutOff", l.CutOff)
+	program.SetFloat(name+".outerCutOff", l.OuterCutOff)
+	program.SetFloat(name+".constant", l.Constant)
+	program.SetFloat(name+".linear", l.Linear)
+	program.SetFloat(name+".quadratic", l.Quadratic)
+	program.SetVec3(name+".ambient", l.Ambient)
+	program.SetVec3(name+".diffuse", l.Diffuse)
+	program.SetVec3(name+".specular", l.Specular)
+}
@@ -0,0 +1,98 @@
+// Package mesh provides GPU-backed triangle meshes and a loader for the
+// Wavefront OBJ format.
+package mesh
+
+import (
+	"unsafe"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+
+	"opengl-testing/gldebug"
+)
+
+// Vertex attribute locations, matching the layout declared in the
+// vertex shader.
+const (
+	AttribPosition = 0
+	AttribColor    = 1
+	AttribNormal   = 2
+	AttribTexCoord = 3
+)
+
+// Vertex is the interleaved per-vertex data uploaded to the GPU.
+// Color defaults to white when a mesh's source has none (e.g. plain
+// OBJ files without the extended vertex-color extension).
+type Vertex struct {
+	Position mgl32.Vec3
+	Color    mgl32.Vec3
+	Normal   mgl32.Vec3
+	TexCoord mgl32.Vec2
+}
+
+const vertexSize = int(unsafe.Sizeof(Vertex{}))
+
+// Mesh is an indexed triangle mesh: a VAO over an interleaved VBO and
+// an EBO, ready to be drawn with Draw.
+type Mesh struct {
+	vao, vbo, ebo uint32
+	indexCount    int32
+}
+
+// New uploads vertices and indices to the GPU and returns the
+// resulting Mesh.
+func New(vertices []Vertex, indices []uint32) *Mesh {
+	m := &Mesh{indexCount: int32(len(indices))}
+
+	gl.GenVertexArrays(1, &m.vao)
+	gl.GenBuffers(1, &m.vbo)
+	gl.GenBuffers(1, &m.ebo)
+
+	gl.BindVertexArray(m.vao)
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, m.vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*vertexSize, unsafe.Pointer(&vertices[0]), gl.STATIC_DRAW)
+	if gldebug.Enabled() {
+		gldebug.CheckError("mesh.New: vertex BufferData")
+	}
+
+	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, m.ebo)
+	gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, len(indices)*4, unsafe.Pointer(&indices[0]), gl.STATIC_DRAW)
+	if gldebug.Enabled() {
+		gldebug.CheckError("mesh.New: index BufferData")
+	}
+
+	gl.VertexAttribPointer(AttribPosition, 3, gl.FLOAT, false, int32(vertexSize), gl.PtrOffset(int(unsafe.Offsetof(Vertex{}.Position))))
+	gl.EnableVertexAttribArray(AttribPosition)
+
+	gl.VertexAttribPointer(AttribColor, 3, gl.FLOAT, false, int32(vertexSize), gl.PtrOffset(int(unsafe.Offsetof(Vertex{}.Color))))
+	gl.EnableVertexAttribArray(AttribColor)
+
+	gl.VertexAttribPointer(AttribNormal, 3, gl.FLOAT, false, int32(vertexSize), gl.PtrOffset(int(unsafe.Offsetof(Vertex{}.Normal))))
+	gl.EnableVertexAttribArray(AttribNormal)
+
+	gl.VertexAttribPointer(AttribTexCoord, 2, gl.FLOAT, false, int32(vertexSize), gl.PtrOffset(int(unsafe.Offsetof(Vertex{}.TexCoord))))
+	gl.EnableVertexAttribArray(AttribTexCoord)
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+	gl.BindVertexArray(0)
+
+	return m
+}
+
+// Draw binds the mesh's VAO and issues an indexed draw call.
+func (m *Mesh) Draw() {
+	gl.BindVertexArray(m.vao)
+	gl.DrawElements(gl.TRIANGLES, m.indexCount, gl.UNSIGNED_INT, nil)
+	if gldebug.Enabled() {
+		gldebug.CheckError("mesh.Mesh.Draw")
+	}
+	gl.BindVertexArray(0)
+}
+
+// Delete releases the mesh's GPU buffers.
+func (m *Mesh) Delete() {
+	gl.DeleteVertexArrays(1, &m.vao)
+	gl.DeleteBuffers(1, &m.vbo)
+	gl.DeleteBuffers(1, &m.ebo)
+}
@@ -0,0 +1,183 @@
+package mesh
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// LoadOBJ parses a Wavefront OBJ file at path into a Mesh. Faces must
+// be triangulated. As an extension, "v" lines may carry an additional
+// r g b triple (six floats instead of three) to supply a per-vertex
+// color; vertices without one default to white.
+func LoadOBJ(path string) (*Mesh, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("mesh: open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var positions []mgl32.Vec3
+	var colors []mgl32.Vec3
+	var texCoords []mgl32.Vec2
+	var normals []mgl32.Vec3
+
+	var vertices []Vertex
+	var indices []uint32
+	seen := make(map[string]uint32)
+
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "v":
+			pos, color, err := parseVertex(fields[1:])
+			if err != nil {
+				return nil, fmt.Errorf("mesh: %s:%d: %w", path, lineNo, err)
+			}
+			positions = append(positions, pos)
+			colors = append(colors, color)
+
+		case "vt":
+			uv, err := parseVec2(fields[1:])
+			if err != nil {
+				return nil, fmt.Errorf("mesh: %s:%d: %w", path, lineNo, err)
+			}
+			texCoords = append(texCoords, uv)
+
+		case "vn":
+			n, err := parseVec3(fields[1:])
+			if err != nil {
+				return nil, fmt.Errorf("mesh: %s:%d: %w", path, lineNo, err)
+			}
+			normals = append(normals, n)
+
+		case "f":
+			if len(fields[1:]) != 3 {
+				return nil, fmt.Errorf("mesh: %s:%d: only triangulated faces are supported", path, lineNo)
+			}
+			for _, ref := range fields[1:] {
+				idx, err := vertexIndex(ref, positions, colors, texCoords, normals, seen, &vertices)
+				if err != nil {
+					return nil, fmt.Errorf("mesh: %s:%d: %w", path, lineNo, err)
+				}
+				indices = append(indices, idx)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("mesh: %s: %w", path, err)
+	}
+	if len(vertices) == 0 {
+		return nil, fmt.Errorf("mesh: %s: no faces found", path)
+	}
+
+	return New(vertices, indices), nil
+}
+
+// vertexIndex resolves one "v/vt/vn" face reference to an index into
+// vertices, deduplicating identical references.
+func vertexIndex(ref string, positions, colors []mgl32.Vec3, texCoords []mgl32.Vec2, normals []mgl32.Vec3, seen map[string]uint32, vertices *[]Vertex) (uint32, error) {
+	if idx, ok := seen[ref]; ok {
+		return idx, nil
+	}
+
+	parts := strings.Split(ref, "/")
+	vi, err := objIndex(parts[0], len(positions))
+	if err != nil {
+		return 0, fmt.Errorf("vertex index: %w", err)
+	}
+
+	v := Vertex{
+		Position: positions[vi],
+		Color:    colors[vi],
+	}
+
+	if len(parts) > 1 && parts[1] != "" {
+		ti, err := objIndex(parts[1], len(texCoords))
+		if err != nil {
+			return 0, fmt.Errorf("texcoord index: %w", err)
+		}
+		v.TexCoord = texCoords[ti]
+	}
+	if len(parts) > 2 && parts[2] != "" {
+		ni, err := objIndex(parts[2], len(normals))
+		if err != nil {
+			return 0, fmt.Errorf("normal index: %w", err)
+		}
+		v.Normal = normals[ni]
+	}
+
+	idx := uint32(len(*vertices))
+	*vertices = append(*vertices, v)
+	seen[ref] = idx
+	return idx, nil
+}
+
+// objIndex converts a 1-based (or negative, relative-to-end) OBJ index
+// into a 0-based slice index.
+func objIndex(s string, count int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		n = count + n + 1
+	}
+	if n < 1 || n > count {
+		return 0, fmt.Errorf("index %d out of range (have %d)", n, count)
+	}
+	return n - 1, nil
+}
+
+func parseVertex(fields []string) (mgl32.Vec3, mgl32.Vec3, error) {
+	if len(fields) != 3 && len(fields) != 6 {
+		return mgl32.Vec3{}, mgl32.Vec3{}, fmt.Errorf("expected 3 or 6 values on v line, got %d", len(fields))
+	}
+	pos, err := parseVec3(fields[:3])
+	if err != nil {
+		return mgl32.Vec3{}, mgl32.Vec3{}, err
+	}
+	if len(fields) == 3 {
+		return pos, mgl32.Vec3{1, 1, 1}, nil
+	}
+	color, err := parseVec3(fields[3:6])
+	if err != nil {
+		return mgl32.Vec3{}, mgl32.Vec3{}, err
+	}
+	return pos, color, nil
+}
+
+func parseVec3(fields []string) (mgl32.Vec3, error) {
+	var v mgl32.Vec3
+	for i := range v {
+		f, err := strconv.ParseFloat(fields[i], 32)
+		if err != nil {
+			return mgl32.Vec3{}, err
+		}
+		v[i] = float32(f)
+	}
+	return v, nil
+}
+
+func parseVec2(fields []string) (mgl32.Vec2, error) {
+	var v mgl32.Vec2
+	for i := range v {
+		f, err := strconv.ParseFloat(fields[i], 32)
+		if err != nil {
+			return mgl32.Vec2{}, err
+		}
+		v[i] = float32(f)
+	}
+	return v, nil
+}
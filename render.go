@@ -1,162 +1,163 @@
 package main
 
 import (
-	"errors"
+	"math"
 	"runtime"
-	"strings"
-	"unsafe"
 
 	"github.com/go-gl/gl/v3.3-core/gl"
 	"github.com/go-gl/glfw/v3.2/glfw"
 	"github.com/go-gl/mathgl/mgl32"
-)
-
-const vertexShaderSrc = `#version 330 core
-layout (location = 0) in vec3 aPos;
-layout (location = 1) in vec3 aColor;
-
-uniform mat4 model;
-uniform mat4 view;
-uniform mat4 projection;
-
-out vec3 ourColor;
 
-void main() {
-	gl_Position = projection * view * model * vec4(aPos, 1.0);
-	ourColor = aColor;
-}` + "\x00"
+	"opengl-testing/camera"
+	"opengl-testing/gldebug"
+	"opengl-testing/lighting"
+	"opengl-testing/mesh"
+	"opengl-testing/shader"
+	"opengl-testing/texture"
+)
 
-const fragmentShaderSrc = `#version 330 core
-in vec3 ourColor;
-out vec4 FragColor;
-void main() {
-	FragColor = vec4(ourColor, 1.0f);
-}` + "\x00"
+const (
+	windowWidth  = 800
+	windowHeight = 640
+)
 
 func init() {
 	runtime.LockOSThread()
 }
 
-func compileShader(src string, shaderType uint32) (uint32, error) {
-	shader := gl.CreateShader(shaderType)
-	srcGlString, free := gl.Strs(src)
-	gl.ShaderSource(shader, 1, srcGlString, nil)
-	free()
-	gl.CompileShader(shader)
+func main() {
+	err := glfw.Init()
+	if err != nil {
+		panic(err)
+	}
+	glfw.WindowHint(glfw.ContextVersionMajor, 3)
+	glfw.WindowHint(glfw.ContextVersionMinor, 3)
+	glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
+	glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.True)
+	window, err := glfw.CreateWindow(windowWidth, windowHeight, "Hello world!", nil, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	window.MakeContextCurrent()
+	window.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
 
-	var status int32
-	gl.GetShaderiv(shader, gl.COMPILE_STATUS, &status)
-	if status == gl.FALSE {
-		var msgLength int32
-		gl.GetShaderiv(shader, gl.INFO_LOG_LENGTH, &msgLength)
-		logBuf := strings.Repeat("\x00", int(msgLength+1))
-		gl.GetShaderInfoLog(shader, msgLength, nil, gl.Str(logBuf))
+	cam := camera.New(mgl32.Vec3{0, 0, 3})
 
-		return 0, errors.New("couldn't compile shader: " + logBuf)
+	// projection is recomputed only when the aspect ratio or FOV
+	// changes (on resize or zoom), not on every frame.
+	var projection mgl32.Mat4
+	updateProjection := func(fbWidth, fbHeight int) {
+		aspect := float32(fbWidth) / float32(fbHeight)
+		projection = mgl32.Perspective(mgl32.DegToRad(cam.FOV), aspect, 0.1, 100.0)
 	}
 
-	return shader, nil
-}
+	firstMouse := true
+	lastX, lastY := float32(windowWidth)/2, float32(windowHeight)/2
+	window.SetCursorPosCallback(func(w *glfw.Window, xpos, ypos float64) {
+		x, y := float32(xpos), float32(ypos)
+		if firstMouse {
+			lastX, lastY = x, y
+			firstMouse = false
+		}
+
+		dx := x - lastX
+		dy := lastY - y
+		lastX, lastY = x, y
+
+		cam.ProcessMouse(dx, dy)
+	})
+	window.SetScrollCallback(func(w *glfw.Window, xoff, yoff float64) {
+		cam.ProcessScroll(float32(yoff))
+		fbWidth, fbHeight := w.GetFramebufferSize()
+		updateProjection(fbWidth, fbHeight)
+	})
+	window.SetFramebufferSizeCallback(func(w *glfw.Window, width, height int) {
+		// On high-DPI displays (notably macOS Retina), the framebuffer
+		// size reported here can be a multiple of the window size set
+		// at creation time, so the viewport and aspect ratio must be
+		// derived from it rather than from windowWidth/windowHeight.
+		gl.Viewport(0, 0, int32(width), int32(height))
+		if gldebug.Enabled() {
+			gldebug.CheckError("gl.Viewport (resize)")
+		}
+		updateProjection(width, height)
+	})
 
-func createProgram(vertexSrc string, fragmentSrc string) (uint32, error) {
-	vertexShader, err := compileShader(vertexSrc, gl.VERTEX_SHADER)
+	err = gl.Init()
 	if err != nil {
-		return 0, err
+		panic(err)
 	}
-	fragmentShader, err := compileShader(fragmentSrc, gl.FRAGMENT_SHADER)
-	if err != nil {
-		return 0, err
+	if gldebug.Enabled() {
+		gldebug.Enable()
+		gldebug.CheckError("gl.Init")
 	}
 
-	shaderProgram := gl.CreateProgram()
-	gl.AttachShader(shaderProgram, vertexShader)
-	gl.AttachShader(shaderProgram, fragmentShader)
-	gl.LinkProgram(shaderProgram)
-	gl.DeleteShader(vertexShader)
-	gl.DeleteShader(fragmentShader)
-
-	var status int32
-	gl.GetProgramiv(shaderProgram, gl.LINK_STATUS, &status)
-	if status == gl.FALSE {
-		var msgLength int32
-		gl.GetProgramiv(shaderProgram, gl.INFO_LOG_LENGTH, &msgLength)
-		logBuf := strings.Repeat("\x00", int(msgLength+1))
-		gl.GetProgramInfoLog(shaderProgram, msgLength, nil, gl.Str(logBuf))
-
-		return 0, errors.New("couldn't link shaders: " + logBuf)
+	fbWidth, fbHeight := window.GetFramebufferSize()
+	gl.Viewport(0, 0, int32(fbWidth), int32(fbHeight))
+	if gldebug.Enabled() {
+		gldebug.CheckError("gl.Viewport (initial)")
 	}
-	return shaderProgram, nil
-}
+	updateProjection(fbWidth, fbHeight)
 
-func main() {
-	err := glfw.Init()
+	cubeProgram, err := shader.NewProgramFromFiles("shaders/cube.vert", "shaders/cube.frag")
 	if err != nil {
 		panic(err)
 	}
-	glfw.WindowHint(glfw.Resizable, glfw.False)
-	glfw.WindowHint(glfw.ContextVersionMajor, 3)
-	glfw.WindowHint(glfw.ContextVersionMinor, 3)
-	glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
-	glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.True)
-	window, err := glfw.CreateWindow(800, 640, "Hello world!", nil, nil)
+	defer cubeProgram.Delete()
+	if err := cubeProgram.Watch(); err != nil {
+		panic(err)
+	}
+
+	lightCubeProgram, err := shader.NewProgramFromFiles("shaders/light_cube.vert", "shaders/light_cube.frag")
 	if err != nil {
 		panic(err)
 	}
+	defer lightCubeProgram.Delete()
+	if err := lightCubeProgram.Watch(); err != nil {
+		panic(err)
+	}
 
-	window.MakeContextCurrent()
+	cubeMesh, err := mesh.LoadOBJ("cube.obj")
+	if err != nil {
+		panic(err)
+	}
+	defer cubeMesh.Delete()
 
-	err = gl.Init()
+	diffuseMap, err := texture.Load("textures/container.png", texture.DefaultOptions())
 	if err != nil {
 		panic(err)
 	}
+	defer diffuseMap.Delete()
 
-	shaderProgram, err := createProgram(vertexShaderSrc, fragmentShaderSrc)
+	specularMap, err := texture.Load("textures/face.png", texture.DefaultOptions())
 	if err != nil {
 		panic(err)
 	}
+	defer specularMap.Delete()
+
+	material := lighting.Material{
+		Diffuse:   0,
+		Specular:  1,
+		Shininess: 32.0,
+	}
 
-	vertices := [...]float32{
-		-0.5, -0.5, -0.5, 1.0, 0.0, 0.0,
-		0.5, -0.5, -0.5, 1.0, 0.0, 0.0,
-		0.5, 0.5, -0.5, 1.0, 0.0, 0.0,
-		0.5, 0.5, -0.5, 1.0, 0.0, 0.0,
-		-0.5, 0.5, -0.5, 1.0, 0.0, 0.0,
-		-0.5, -0.5, -0.5, 1.0, 0.0, 0.0,
-
-		-0.5, -0.5, 0.5, 0.0, 1.0, 0.0,
-		0.5, -0.5, 0.5, 0.0, 1.0, 0.0,
-		0.5, 0.5, 0.5, 0.0, 1.0, 0.0,
-		0.5, 0.5, 0.5, 0.0, 1.0, 0.0,
-		-0.5, 0.5, 0.5, 0.0, 1.0, 0.0,
-		-0.5, -0.5, 0.5, 0.0, 1.0, 0.0,
-
-		-0.5, 0.5, 0.5, 0.0, 0.0, 1.0,
-		-0.5, 0.5, -0.5, 0.0, 0.0, 1.0,
-		-0.5, -0.5, -0.5, 0.0, 0.0, 1.0,
-		-0.5, -0.5, -0.5, 0.0, 0.0, 1.0,
-		-0.5, -0.5, 0.5, 0.0, 0.0, 1.0,
-		-0.5, 0.5, 0.5, 0.0, 0.0, 1.0,
-
-		0.5, 0.5, 0.5, 1.0, 1.0, 0.0,
-		0.5, 0.5, -0.5, 1.0, 1.0, 0.0,
-		0.5, -0.5, -0.5, 1.0, 1.0, 0.0,
-		0.5, -0.5, -0.5, 1.0, 1.0, 0.0,
-		0.5, -0.5, 0.5, 1.0, 1.0, 0.0,
-		0.5, 0.5, 0.5, 1.0, 1.0, 0.0,
-
-		-0.5, -0.5, -0.5, 1.0, 0.0, 1.0,
-		0.5, -0.5, -0.5, 1.0, 0.0, 1.0,
-		0.5, -0.5, 0.5, 1.0, 0.0, 1.0,
-		0.5, -0.5, 0.5, 1.0, 0.0, 1.0,
-		-0.5, -0.5, 0.5, 1.0, 0.0, 1.0,
-		-0.5, -0.5, -0.5, 1.0, 0.0, 1.0,
-
-		-0.5, 0.5, -0.5, 0.0, 1.0, 1.0,
-		0.5, 0.5, -0.5, 0.0, 1.0, 1.0,
-		0.5, 0.5, 0.5, 0.0, 1.0, 1.0,
-		0.5, 0.5, 0.5, 0.0, 1.0, 1.0,
-		-0.5, 0.5, 0.5, 0.0, 1.0, 1.0,
-		-0.5, 0.5, -0.5, 0.0, 1.0, 1.0,
+	dirLight := lighting.DirectionalLight{
+		Direction: mgl32.Vec3{-0.2, -1.0, -0.3},
+		Ambient:   mgl32.Vec3{0.05, 0.05, 0.05},
+		Diffuse:   mgl32.Vec3{0.4, 0.4, 0.4},
+		Specular:  mgl32.Vec3{0.5, 0.5, 0.5},
+	}
+
+	pointLightPos := mgl32.Vec3{1.2, 1.0, 2.0}
+	pointLight := lighting.PointLight{
+		Position:  pointLightPos,
+		Constant:  1.0,
+		Linear:    0.09,
+		Quadratic: 0.032,
+		Ambient:   mgl32.Vec3{0.05, 0.05, 0.05},
+		Diffuse:   mgl32.Vec3{0.8, 0.8, 0.8},
+		Specular:  mgl32.Vec3{1.0, 1.0, 1.0},
 	}
 
 	cubePositions := [...]mgl32.Vec3{
@@ -173,61 +174,80 @@ func main() {
 	}
 
 	gl.Enable(gl.DEPTH_TEST)
+	if gldebug.Enabled() {
+		gldebug.CheckError("gl.Enable(DEPTH_TEST)")
+	}
 
-	var VBO, VAO, EBO uint32
-	gl.GenVertexArrays(1, &VAO)
-	gl.GenBuffers(1, &VBO)
-	gl.GenBuffers(1, &EBO)
-	gl.BindVertexArray(VAO)
-
-	gl.BindBuffer(gl.ARRAY_BUFFER, VBO)
-	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, unsafe.Pointer(&vertices[0]), gl.STATIC_DRAW)
-
-	gl.VertexAttribPointer(0, 3, gl.FLOAT, false, 6*4, gl.PtrOffset(0))
-	gl.EnableVertexAttribArray(0)
-
-	gl.VertexAttribPointer(1, 3, gl.FLOAT, false, 6*4, gl.PtrOffset(3*4))
-	gl.EnableVertexAttribArray(1)
-
-	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
-	gl.BindVertexArray(0)
-
-	modelLocation := gl.GetUniformLocation(shaderProgram, gl.Str("model\x00"))
-	viewLocation := gl.GetUniformLocation(shaderProgram, gl.Str("view\x00"))
-	projectionLocation := gl.GetUniformLocation(shaderProgram, gl.Str("projection\x00"))
-
+	var lastFrame float32
 	for !window.ShouldClose() {
+		currentFrame := float32(glfw.GetTime())
+		dt := currentFrame - lastFrame
+		lastFrame = currentFrame
+
 		if window.GetKey(glfw.KeyEscape) == glfw.Press {
 			window.SetShouldClose(true)
 		}
+		cam.SetMoving(camera.Forward, window.GetKey(glfw.KeyW) == glfw.Press)
+		cam.SetMoving(camera.Backward, window.GetKey(glfw.KeyS) == glfw.Press)
+		cam.SetMoving(camera.Left, window.GetKey(glfw.KeyA) == glfw.Press)
+		cam.SetMoving(camera.Right, window.GetKey(glfw.KeyD) == glfw.Press)
+		cam.ProcessKeyboard(dt)
+
+		cubeProgram.Poll()
+		lightCubeProgram.Poll()
 
 		gl.ClearColor(0.0, 0.0, 0.0, 1.0)
 		gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+		if gldebug.Enabled() {
+			gldebug.CheckError("gl.Clear")
+		}
 
-		gl.BindVertexArray(VAO)
-		gl.UseProgram(shaderProgram)
+		view := cam.ViewMatrix()
+
+		spotLight := lighting.SpotLight{
+			Position:    cam.Position,
+			Direction:   cam.Front,
+			CutOff:      float32(math.Cos(float64(mgl32.DegToRad(12.5)))),
+			OuterCutOff: float32(math.Cos(float64(mgl32.DegToRad(15.0)))),
+			Constant:    1.0,
+			Linear:      0.09,
+			Quadratic:   0.032,
+			Ambient:     mgl32.Vec3{0.0, 0.0, 0.0},
+			Diffuse:     mgl32.Vec3{1.0, 1.0, 1.0},
+			Specular:    mgl32.Vec3{1.0, 1.0, 1.0},
+		}
+
+		diffuseMap.Bind(0)
+		specularMap.Bind(1)
 
-		view := mgl32.Ident4().Mul4(mgl32.Translate3D(0.0, 0.0, -3.0))
-		projection := mgl32.Ident4().Mul4(mgl32.Perspective(mgl32.DegToRad(45.0), 800.0/640.0, 0.1, 100.0))
-		gl.UniformMatrix4fv(viewLocation, 1, false, &view[0])
-		gl.UniformMatrix4fv(projectionLocation, 1, false, &projection[0])
+		cubeProgram.Use()
+		cubeProgram.SetMat4("view", view)
+		cubeProgram.SetMat4("projection", projection)
+		cubeProgram.SetVec3("viewPos", cam.Position)
+		material.Upload(cubeProgram, "material")
+		dirLight.Upload(cubeProgram, "dirLight")
+		pointLight.Upload(cubeProgram, "pointLight")
+		spotLight.Upload(cubeProgram, "spotLight")
 
 		for i := 0; i < 10; i++ {
 			angle := 20.0*float32(i) + float32(glfw.GetTime())*50.0
 			model := mgl32.Ident4()
 			model = model.Mul4(mgl32.Translate3D(cubePositions[i][0], cubePositions[i][1], cubePositions[i][2]))
 			model = model.Mul4(mgl32.HomogRotate3D(mgl32.DegToRad(angle), mgl32.Vec3{0.5, 1.0, 0.0}.Normalize()))
-			gl.UniformMatrix4fv(modelLocation, 1, false, &model[0])
-			gl.DrawArrays(gl.TRIANGLES, 0, 36)
+			cubeProgram.SetMat4("model", model)
+			cubeMesh.Draw()
 		}
 
+		lightCubeProgram.Use()
+		lightCubeProgram.SetMat4("view", view)
+		lightCubeProgram.SetMat4("projection", projection)
+		lightModel := mgl32.Translate3D(pointLightPos[0], pointLightPos[1], pointLightPos[2]).Mul4(mgl32.Scale3D(0.2, 0.2, 0.2))
+		lightCubeProgram.SetMat4("model", lightModel)
+		cubeMesh.Draw()
+
 		window.SwapBuffers()
 		glfw.PollEvents()
 	}
 
-	gl.DeleteVertexArrays(1, &VAO)
-	gl.DeleteBuffers(1, &VBO)
-	gl.DeleteBuffers(1, &EBO)
-
 	glfw.Terminate()
 }
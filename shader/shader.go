@@ -0,0 +1,164 @@
+// Package shader wraps GLSL program compilation and uniform upload,
+// and supports hot-reloading a program's sources from disk while the
+// application runs.
+package shader
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+
+	"opengl-testing/gldebug"
+)
+
+// Program is a linked GLSL program together with a cache of its
+// uniform locations and, once Watch has been called, the state needed
+// to hot-reload it from its source files.
+type Program struct {
+	id       uint32
+	uniforms map[string]int32
+
+	vertPath, fragPath string
+	reload             chan struct{}
+	watchDone          chan struct{}
+}
+
+// NewProgramFromFiles reads, compiles and links the vertex and
+// fragment shaders at vertPath and fragPath.
+func NewProgramFromFiles(vertPath, fragPath string) (*Program, error) {
+	id, err := buildProgram(vertPath, fragPath)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{
+		id:       id,
+		uniforms: make(map[string]int32),
+		vertPath: vertPath,
+		fragPath: fragPath,
+	}, nil
+}
+
+func buildProgram(vertPath, fragPath string) (uint32, error) {
+	vertSrc, err := os.ReadFile(vertPath)
+	if err != nil {
+		return 0, err
+	}
+	fragSrc, err := os.ReadFile(fragPath)
+	if err != nil {
+		return 0, err
+	}
+	return createProgram(string(vertSrc)+"\x00", string(fragSrc)+"\x00")
+}
+
+// Use installs this program as the active one for subsequent draw
+// calls.
+func (p *Program) Use() {
+	gl.UseProgram(p.id)
+}
+
+// SetMat4 uploads m to the uniform named name.
+func (p *Program) SetMat4(name string, m mgl32.Mat4) {
+	gl.UniformMatrix4fv(p.location(name), 1, false, &m[0])
+	if gldebug.Enabled() {
+		gldebug.CheckError("shader.Program.SetMat4(" + name + ")")
+	}
+}
+
+// SetVec3 uploads v to the uniform named name.
+func (p *Program) SetVec3(name string, v mgl32.Vec3) {
+	gl.Uniform3fv(p.location(name), 1, &v[0])
+	if gldebug.Enabled() {
+		gldebug.CheckError("shader.Program.SetVec3(" + name + ")")
+	}
+}
+
+// SetFloat uploads v to the uniform named name.
+func (p *Program) SetFloat(name string, v float32) {
+	gl.Uniform1f(p.location(name), v)
+	if gldebug.Enabled() {
+		gldebug.CheckError("shader.Program.SetFloat(" + name + ")")
+	}
+}
+
+// SetInt uploads v to the uniform named name.
+func (p *Program) SetInt(name string, v int32) {
+	gl.Uniform1i(p.location(name), v)
+	if gldebug.Enabled() {
+		gldebug.CheckError("shader.Program.SetInt(" + name + ")")
+	}
+}
+
+// location looks up name in the uniform cache, querying and caching
+// it on first use.
+func (p *Program) location(name string) int32 {
+	if loc, ok := p.uniforms[name]; ok {
+		return loc
+	}
+	loc := gl.GetUniformLocation(p.id, gl.Str(name+"\x00"))
+	if gldebug.Enabled() {
+		gldebug.CheckError("shader.Program.location(" + name + ")")
+	}
+	p.uniforms[name] = loc
+	return loc
+}
+
+// Delete releases the underlying GL program object and stops any
+// active Watch.
+func (p *Program) Delete() {
+	p.stopWatch()
+	gl.DeleteProgram(p.id)
+}
+
+func compileShader(src string, shaderType uint32) (uint32, error) {
+	shader := gl.CreateShader(shaderType)
+	srcGlString, free := gl.Strs(src)
+	gl.ShaderSource(shader, 1, srcGlString, nil)
+	free()
+	gl.CompileShader(shader)
+
+	var status int32
+	gl.GetShaderiv(shader, gl.COMPILE_STATUS, &status)
+	if status == gl.FALSE {
+		var msgLength int32
+		gl.GetShaderiv(shader, gl.INFO_LOG_LENGTH, &msgLength)
+		logBuf := strings.Repeat("\x00", int(msgLength+1))
+		gl.GetShaderInfoLog(shader, msgLength, nil, gl.Str(logBuf))
+
+		return 0, errors.New("couldn't compile shader: " + logBuf)
+	}
+
+	return shader, nil
+}
+
+func createProgram(vertexSrc string, fragmentSrc string) (uint32, error) {
+	vertexShader, err := compileShader(vertexSrc, gl.VERTEX_SHADER)
+	if err != nil {
+		return 0, err
+	}
+	fragmentShader, err := compileShader(fragmentSrc, gl.FRAGMENT_SHADER)
+	if err != nil {
+		return 0, err
+	}
+
+	program := gl.CreateProgram()
+	gl.AttachShader(program, vertexShader)
+	gl.AttachShader(program, fragmentShader)
+	gl.LinkProgram(program)
+	gl.DeleteShader(vertexShader)
+	gl.DeleteShader(fragmentShader)
+
+	var status int32
+	gl.GetProgramiv(program, gl.LINK_STATUS, &status)
+	if status == gl.FALSE {
+		var msgLength int32
+		gl.GetProgramiv(program, gl.INFO_LOG_LENGTH, &msgLength)
+		logBuf := strings.Repeat("\x00", int(msgLength+1))
+		gl.GetProgramInfoLog(program, msgLength, nil, gl.Str(logBuf))
+
+		return 0, errors.New("couldn't link shaders: " + logBuf)
+	}
+	return program, nil
+}
@@ -0,0 +1,90 @@
+package shader
+
+import (
+	"log"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-gl/gl/v3.3-core/gl"
+)
+
+// Watch starts watching this program's vertex and fragment source
+// files for edits. Detected changes are queued, not applied
+// immediately, since relinking touches the GL context and must happen
+// on the render thread — call Poll once per frame from there to drain
+// the queue and apply them.
+func (p *Program) Watch() error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := w.Add(p.vertPath); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Add(p.fragPath); err != nil {
+		w.Close()
+		return err
+	}
+
+	p.reload = make(chan struct{}, 1)
+	p.watchDone = make(chan struct{})
+
+	go func() {
+		defer w.Close()
+		for {
+			select {
+			case event, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+					select {
+					case p.reload <- struct{}{}:
+					default:
+					}
+				}
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("shader: watch %s/%s: %v", p.vertPath, p.fragPath, err)
+			case <-p.watchDone:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Poll applies any shader edits queued by Watch, relinking the
+// program in place. Compile and link errors are logged and non-fatal:
+// the previous program is kept bound and nothing else changes.
+func (p *Program) Poll() {
+	if p.reload == nil {
+		return
+	}
+	select {
+	case <-p.reload:
+		id, err := buildProgram(p.vertPath, p.fragPath)
+		if err != nil {
+			log.Printf("shader: reload %s/%s failed: %v", p.vertPath, p.fragPath, err)
+			return
+		}
+		gl.DeleteProgram(p.id)
+		p.id = id
+		p.uniforms = make(map[string]int32)
+		log.Printf("shader: reloaded %s/%s", p.vertPath, p.fragPath)
+	default:
+	}
+}
+
+// stopWatch shuts down the background watch goroutine, if one is
+// running.
+func (p *Program) stopWatch() {
+	if p.watchDone == nil {
+		return
+	}
+	close(p.watchDone)
+	p.watchDone = nil
+	p.reload = nil
+}
@@ -0,0 +1,105 @@
+// Package texture loads images from disk into OpenGL 2D textures.
+package texture
+
+import (
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+)
+
+// Texture wraps an OpenGL 2D texture object.
+type Texture struct {
+	id uint32
+}
+
+// Options configures wrap and filter modes applied when a Texture is
+// loaded. The zero value selects repeat wrapping and linear
+// mipmapped filtering.
+type Options struct {
+	WrapS, WrapT    int32
+	MinFilter       int32
+	MagFilter       int32
+	GenerateMipmaps bool
+}
+
+// DefaultOptions returns the Options used when none are given to Load.
+func DefaultOptions() Options {
+	return Options{
+		WrapS:           gl.REPEAT,
+		WrapT:           gl.REPEAT,
+		MinFilter:       gl.LINEAR_MIPMAP_LINEAR,
+		MagFilter:       gl.LINEAR,
+		GenerateMipmaps: true,
+	}
+}
+
+// Load decodes the PNG or JPEG image at path and uploads it as a 2D
+// texture. A zero Options value is replaced with DefaultOptions.
+func Load(path string, opts Options) (*Texture, error) {
+	if opts == (Options{}) {
+		opts = DefaultOptions()
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("texture: open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("texture: decode %s: %w", path, err)
+	}
+
+	rgba := toRGBA(img)
+	bounds := rgba.Bounds()
+	width, height := int32(bounds.Dx()), int32(bounds.Dy())
+
+	var id uint32
+	gl.GenTextures(1, &id)
+	gl.BindTexture(gl.TEXTURE_2D, id)
+
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, opts.WrapS)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, opts.WrapT)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, opts.MinFilter)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, opts.MagFilter)
+
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA, width, height, 0, gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(rgba.Pix))
+	if opts.GenerateMipmaps {
+		gl.GenerateMipmap(gl.TEXTURE_2D)
+	}
+
+	gl.BindTexture(gl.TEXTURE_2D, 0)
+
+	return &Texture{id: id}, nil
+}
+
+// Bind activates texture unit `unit` and binds this texture to it.
+func (t *Texture) Bind(unit uint32) {
+	gl.ActiveTexture(gl.TEXTURE0 + unit)
+	gl.BindTexture(gl.TEXTURE_2D, t.id)
+}
+
+// Delete releases the underlying GL texture object.
+func (t *Texture) Delete() {
+	gl.DeleteTextures(1, &t.id)
+}
+
+// toRGBA converts any image.Image into a tightly packed *image.RGBA,
+// flipping it vertically so row 0 ends up at the texture's bottom as
+// OpenGL expects.
+func toRGBA(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	rgba := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			rgba.Set(x, h-1-y, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return rgba
+}